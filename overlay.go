@@ -1,12 +1,17 @@
 package bubble_overlay
 
 import (
+	"fmt"
+	"math"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/harmonica"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
 )
 
 // Internal ID management. Used during animating to assure that frame messages
@@ -24,11 +29,20 @@ func nextID() int {
 	return lastID
 }
 
-// const (
-// 	fps              = 60
-// 	defaultFrequency = 18.0
-// 	defaultDamping   = 1.0
-// )
+const (
+	fps              = 60
+	defaultFrequency = 18.0
+	defaultDamping   = 1.0
+
+	animationEpsilon = 0.001
+
+	closeGlyph = "[x]"
+
+	countdownRate = time.Second / 10
+
+	filledBlock = "█"
+	emptyBlock  = "░"
+)
 
 var (
 	defaultOverlayStyle = lipgloss.NewStyle().
@@ -44,10 +58,24 @@ var (
 )
 
 // FrameMsg indicates that an animation step should occur.
-// type FrameMsg struct {
-// 	id  int
-// 	tag int
-// }
+type FrameMsg struct {
+	id  int
+	tag int
+}
+
+// EntranceDirection controls where the overlay animates in from while
+// opening (and back out to while closing). The zero value, EntranceFade,
+// keeps the overlay at its aligned position and blends it in instead of
+// sliding it.
+type EntranceDirection int
+
+const (
+	EntranceFade EntranceDirection = iota
+	EntranceTop
+	EntranceBottom
+	EntranceLeft
+	EntranceRight
+)
 
 // TimeoutMsg indicates that the overlay should be opened or closed
 type TimeoutMsg struct {
@@ -56,6 +84,62 @@ type TimeoutMsg struct {
 	state bool
 }
 
+// CountdownMsg drives the visible OpenTimeout/CloseTimeout countdown,
+// ticking at a faster rate than TimeoutMsg so the indicator animates
+// smoothly rather than just jumping at the end.
+type CountdownMsg struct {
+	id  int
+	tag int
+}
+
+// TimeoutIndicatorPos controls where, if anywhere, the OpenTimeout/
+// CloseTimeout countdown is shown. The zero value, TimeoutIndicatorNone,
+// shows no indicator at all.
+type TimeoutIndicatorPos int
+
+const (
+	TimeoutIndicatorNone TimeoutIndicatorPos = iota
+	TimeoutInTitle
+	TimeoutInFooter
+	TimeoutProgressBar
+)
+
+// ConfirmMsg is emitted when the Keymap.Confirm binding is pressed while
+// the overlay is open and focused. The overlay has no opinion on what
+// confirming means, so it doesn't change its own state here — it's up to
+// the parent program (or Content) to react, e.g. by submitting a form.
+type ConfirmMsg struct{}
+
+// KeyMap is the set of key bindings the overlay itself reacts to. Close
+// lets the overlay dismiss itself (e.g. Esc) without the parent app having
+// to wire that up; Open reopens a closed overlay the same way. Confirm
+// emits a ConfirmMsg instead, since only the parent/Content knows what
+// confirming should do.
+type KeyMap struct {
+	Open    key.Binding
+	Close   key.Binding
+	Confirm key.Binding
+}
+
+// DefaultKeyMap returns the KeyMap used when none is supplied via
+// WithKeymap.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Open: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "open"),
+		),
+		Close: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "close"),
+		),
+		Confirm: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "confirm"),
+		),
+	}
+}
+
 type Model struct {
 	// An identifier to keep us from receiving messages intended for other
 	// overlays.
@@ -78,10 +162,27 @@ type Model struct {
 	OpenTimeout  time.Duration // the duration to keep the overlay open
 	CloseTimeout time.Duration // the duration to keep the overlay closed
 
+	TimeoutIndicator TimeoutIndicatorPos // where, if anywhere, to show the OpenTimeout/CloseTimeout countdown
+
 	MouseHide []tea.MouseEventType // the mouse events to hide the overlay
 
+	Content tea.Model // an optional interactive child model to host, e.g. a textarea or list
+	Keymap  KeyMap    // key bindings the overlay reacts to itself
+
+	Title       string         // the titlebar text; no titlebar is drawn when empty and CloseButton is false
+	TitleStyle  lipgloss.Style // the style applied to Title
+	Footer      string         // the footer text; no footer row is drawn when empty
+	CloseButton bool           // whether to draw a "[x]" close affordance in the titlebar
+
+	entranceDir EntranceDirection // the direction the overlay animates in from
+
 	opened      bool      // whether the overlay is currently open
+	focused     bool      // whether Content receives keyboard/mouse messages
 	springStart time.Time // the time the overlay animation was started
+
+	spring   harmonica.Spring // drives progress toward its target
+	progress float64          // 0 = fully closed, 1 = fully open
+	velocity float64          // progress's rate of change, owned by spring
 }
 
 type Option func(*Model)
@@ -125,6 +226,15 @@ func WithTimeout(open time.Duration, close time.Duration) Option {
 	}
 }
 
+// WithTimeoutIndicator draws a visible countdown toward the active
+// OpenTimeout/CloseTimeout at pos. Has no effect when both timeouts are
+// zero.
+func WithTimeoutIndicator(pos TimeoutIndicatorPos) Option {
+	return func(m *Model) {
+		m.TimeoutIndicator = pos
+	}
+}
+
 // set the alignment of the overlay on the backdrop
 // defaults to center, center
 func WithAlignment(vpos, hpos lipgloss.Position) Option {
@@ -140,6 +250,71 @@ func WithMouseHide(events ...tea.MouseEventType) Option {
 	}
 }
 
+// WithSpring configures the harmonica spring driving the open/close
+// animation. Higher frequency snaps faster; higher damping settles with
+// less bounce.
+func WithSpring(frequency, damping float64) Option {
+	return func(m *Model) {
+		m.spring = harmonica.NewSpring(harmonica.FPS(fps), frequency, damping)
+	}
+}
+
+// WithEntranceDirection sets the side the overlay slides in from when
+// opening (and recedes to when closing). Defaults to EntranceFade.
+func WithEntranceDirection(dir EntranceDirection) Option {
+	return func(m *Model) {
+		m.entranceDir = dir
+	}
+}
+
+// WithContent hosts an interactive child model (textarea, list, viewport,
+// form, ...) as the overlay's content instead of a static string. Its
+// View() is used in place of Overlay, and it receives tea.WindowSizeMsg
+// plus, once the overlay is Focus()ed, keyboard and mouse messages.
+func WithContent(child tea.Model) Option {
+	return func(m *Model) {
+		m.Content = child
+	}
+}
+
+// WithKeymap overrides the key bindings the overlay reacts to itself.
+// Defaults to DefaultKeyMap().
+func WithKeymap(km KeyMap) Option {
+	return func(m *Model) {
+		m.Keymap = km
+	}
+}
+
+// WithTitle draws a titlebar above the overlay's content with the given
+// text left-aligned.
+func WithTitle(title string) Option {
+	return func(m *Model) {
+		m.Title = title
+	}
+}
+
+// WithTitleStyle sets the style applied to the titlebar text.
+func WithTitleStyle(style lipgloss.Style) Option {
+	return func(m *Model) {
+		m.TitleStyle = style
+	}
+}
+
+// WithFooter draws a footer row below the overlay's content.
+func WithFooter(footer string) Option {
+	return func(m *Model) {
+		m.Footer = footer
+	}
+}
+
+// WithCloseButton draws a "[x]" glyph right-aligned in the titlebar that
+// closes the overlay when clicked.
+func WithCloseButton(v bool) Option {
+	return func(m *Model) {
+		m.CloseButton = v
+	}
+}
+
 func New(opts ...Option) Model {
 	m := Model{
 		id:            nextID(),
@@ -147,12 +322,10 @@ func New(opts ...Option) Model {
 		BackdropStyle: defaultBackdropStyle,
 		Vertical:      lipgloss.Center,
 		Horizontal:    lipgloss.Center,
+		Keymap:        DefaultKeyMap(),
+		spring:        harmonica.NewSpring(harmonica.FPS(fps), defaultFrequency, defaultDamping),
 	}
 
-	// if !m.springCustomized {
-	// 	m.SetSpringOptions(defaultFrequency, defaultDamping)
-	// }
-
 	for _, opt := range opts {
 		opt(&m)
 	}
@@ -160,6 +333,9 @@ func New(opts ...Option) Model {
 }
 
 func (m Model) Init() tea.Cmd {
+	if m.Content != nil {
+		return m.Content.Init()
+	}
 	return nil
 }
 
@@ -167,16 +343,39 @@ func (m *Model) Open() tea.Cmd {
 	return m.open(true)
 }
 
+// Focus lets the embedded Content model receive keyboard and mouse
+// messages.
+func (m *Model) Focus() {
+	m.focused = true
+}
+
+// Blur stops forwarding keyboard and mouse messages to the embedded
+// Content model.
+func (m *Model) Blur() {
+	m.focused = false
+}
+
+// Focused reports whether the embedded Content model currently receives
+// keyboard and mouse messages.
+func (m *Model) Focused() bool {
+	return m.focused
+}
+
 func (m *Model) open(withTimeout bool) tea.Cmd {
 	m.opened = true
 	m.springStart = time.Now()
+	m.tag++
+
+	cmds := []tea.Cmd{m.nextFrame()}
 
 	// register message to fire at the end of openTImeout
 	if withTimeout && m.OpenTimeout != 0 {
-		m.tag++
-		return m.nextTimeout(m.OpenTimeout)
+		cmds = append(cmds, m.nextTimeout(m.OpenTimeout))
+		if m.TimeoutIndicator != TimeoutIndicatorNone {
+			cmds = append(cmds, m.nextCountdown())
+		}
 	}
-	return nil
+	return tea.Batch(cmds...)
 }
 
 func (m *Model) Close() tea.Cmd {
@@ -186,14 +385,19 @@ func (m *Model) Close() tea.Cmd {
 func (m *Model) close(withTimeout bool) tea.Cmd {
 	m.opened = false
 	m.springStart = time.Now()
+	m.tag++
+
+	cmds := []tea.Cmd{m.nextFrame()}
 
 	// register message to fire at the end of closeTimeout
 	if withTimeout && m.CloseTimeout != 0 {
-		m.tag++
-		return m.nextTimeout(m.CloseTimeout)
+		cmds = append(cmds, m.nextTimeout(m.CloseTimeout))
+		if m.TimeoutIndicator != TimeoutIndicatorNone {
+			cmds = append(cmds, m.nextCountdown())
+		}
 	}
 
-	return nil
+	return tea.Batch(cmds...)
 }
 
 func (m *Model) nextTimeout(d time.Duration) tea.Cmd {
@@ -202,44 +406,254 @@ func (m *Model) nextTimeout(d time.Duration) tea.Cmd {
 	})
 }
 
+// nextFrame schedules the next animation step, tagged so that a
+// subsequent Open/Close invalidates any ticks already in flight.
+func (m *Model) nextFrame() tea.Cmd {
+	id, tag := m.id, m.tag
+	return tea.Tick(time.Second/fps, func(time.Time) tea.Msg {
+		return FrameMsg{id: id, tag: tag}
+	})
+}
+
+// nextCountdown schedules the next countdown refresh, tagged so that a
+// subsequent Open/Close invalidates any ticks already in flight.
+func (m *Model) nextCountdown() tea.Cmd {
+	id, tag := m.id, m.tag
+	return tea.Tick(countdownRate, func(time.Time) tea.Msg {
+		return CountdownMsg{id: id, tag: tag}
+	})
+}
+
+// activeTimeout returns the timeout duration relevant to the overlay's
+// current state: OpenTimeout while open, CloseTimeout while closed.
+func (m *Model) activeTimeout() time.Duration {
+	if m.opened {
+		return m.OpenTimeout
+	}
+	return m.CloseTimeout
+}
+
+// timeoutRemaining returns how much of the active timeout is left, or
+// zero if there's no active timeout or it has already elapsed.
+func (m *Model) timeoutRemaining() time.Duration {
+	total := m.activeTimeout()
+	if total == 0 {
+		return 0
+	}
+	remaining := total - time.Since(m.springStart)
+	if remaining < 0 {
+		remaining = 0
+	}
+	return remaining
+}
+
+// target returns the progress value the spring is animating toward.
+func (m *Model) target() float64 {
+	if m.opened {
+		return 1
+	}
+	return 0
+}
+
+// animating reports whether progress (or its velocity) has yet to settle
+// at its target.
+func (m *Model) animating() bool {
+	return math.Abs(m.progress-m.target()) > animationEpsilon || math.Abs(m.velocity) > animationEpsilon
+}
+
 func (m *Model) Render(overlay, backdrop string) string {
-	if !m.opened {
+	if !m.opened && !m.animating() {
 		return backdrop
 	}
 
-	bw, bh := lipgloss.Size(backdrop)
-	ow, oh := lipgloss.Size(overlay)
-	// calculate the position of the overlay
-	//  (backdropWidth - overlayWidth) * horizontalAlignment = leftOffset
-	leftOffset := int(float64(bw-ow) * float64(m.Horizontal)) // 0 <= leftOffset <= backdropWidth - overlayWidth
-	//  (backdropHeight - overlayHeight) * verticalAlignment = topOffset
-	topOffset := int(float64(bh-oh) * float64(m.Vertical)) // 0 <= topOffset <= backdropHeight - overlayHeight
+	if m.entranceDir == EntranceFade && m.progress < 1 {
+		overlay = lipgloss.NewStyle().Faint(true).Render(overlay)
+	}
+
+	topOffset, leftOffset, bw, _, ow, _ := m.offsets(overlay, backdrop)
+
+	// clip the overlay's horizontal span to the backdrop; an EntranceLeft/
+	// EntranceRight animation parks leftOffset before column 0 or past
+	// bw-ow while sliding the overlay on/off screen
+	visibleStart, visibleEnd := leftOffset, leftOffset+ow
+	if visibleStart < 0 {
+		visibleStart = 0
+	}
+	if visibleEnd > bw {
+		visibleEnd = bw
+	}
+	if visibleEnd <= visibleStart {
+		// the overlay has slid entirely off the left or right edge
+		return backdrop
+	}
 
-	// splice the overlay into the backdrop at the calculated offsets
+	// splice the overlay into the backdrop at the calculated offsets, cutting
+	// each backdrop line on visible cell boundaries so wide runes and ANSI
+	// styling survive the cut intact
 	blines := strings.Split(backdrop, "\n")
 	olines := strings.Split(overlay, "\n")
-	for i := topOffset; i < topOffset+len(olines); i++ {
-		// replace the line starting with line[yOffset] at position xOffset to overlayWidth
+	rightWidth := bw - visibleEnd
+	for idx, oline := range olines {
+		i := topOffset + idx
+		if i < 0 || i >= len(blines) {
+			// row has animated off the top/bottom of the backdrop
+			continue
+		}
 		line := blines[i]
 
-		left := lipgloss.NewStyle().MaxWidth(leftOffset).Render(line)
-		leftSub := lipgloss.NewStyle().MaxWidth(leftOffset + ow).Render(line)
+		visible, _ := cellSlice(oline, visibleStart-leftOffset, visibleEnd-visibleStart)
 
-		// right = line - leftSub
-		// right := strings.Replace(line, leftSub, "", 1)
-		right := string([]byte(line)[len([]byte(leftSub)):])
+		left, leftCells := cellSlice(line, 0, visibleStart)
+		if pad := visibleStart - leftCells; pad > 0 {
+			left += m.BackdropStyle.Render(strings.Repeat(" ", pad))
+		}
 
-		idx := i - topOffset
-		blines[i] = left + olines[idx] + right
+		right, rightCells := cellSlice(line, visibleEnd, rightWidth)
+		if pad := rightWidth - rightCells; pad > 0 {
+			right += m.BackdropStyle.Render(strings.Repeat(" ", pad))
+		}
+
+		blines[i] = left + visible + right
 	}
 
 	return strings.Join(blines, "\n")
 }
 
+// cellSlice returns the substring of s spanning exactly width visible
+// cells starting at visible cell start, honoring ANSI SGR escape sequences
+// and never splitting a double-width rune. Any SGR state still active at
+// the cut points is replayed/reset so the returned piece renders correctly
+// on its own. cells reports how many cells were actually collected, which
+// is less than width when s has fewer than start+width visible cells.
+func cellSlice(s string, start, width int) (out string, cells int) {
+	if width <= 0 {
+		return "", 0
+	}
+
+	var b strings.Builder
+	var active string
+	entered := false
+	cell := 0
+	runes := []rune(s)
+
+	enter := func() {
+		if !entered {
+			if active != "" {
+				b.WriteString(active)
+			}
+			entered = true
+		}
+	}
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		if r == 0x1b && i+1 < len(runes) && runes[i+1] == '[' {
+			j := i + 2
+			for j < len(runes) && !(runes[j] >= 0x40 && runes[j] <= 0x7e) {
+				j++
+			}
+			if j >= len(runes) {
+				break // unterminated escape sequence; stop here
+			}
+			seq := string(runes[i : j+1])
+			if cell >= start && cell < start+width {
+				enter()
+				b.WriteString(seq)
+			}
+			if runes[j] == 'm' {
+				if seq == "\x1b[0m" || seq == "\x1b[m" {
+					active = ""
+				} else {
+					active += seq
+				}
+			}
+			i = j + 1
+			continue
+		}
+
+		w := runewidth.RuneWidth(r)
+		if cell+w > start+width {
+			break // this rune straddles the cut; stop before it
+		}
+		if cell >= start {
+			enter()
+			b.WriteRune(r)
+			cells += w
+		}
+		cell += w
+		i++
+	}
+
+	if entered && active != "" {
+		b.WriteString("\x1b[0m")
+	}
+	return b.String(), cells
+}
+
+// offsets computes where overlay sits on backdrop: its aligned, animated
+// (topOffset, leftOffset), plus both strings' cell dimensions. leftOffset
+// can fall outside [0, bw-ow] while an EntranceLeft/EntranceRight
+// animation is sliding the overlay on/off screen; Render is responsible
+// for clipping it against the backdrop's bounds.
+func (m *Model) offsets(overlay, backdrop string) (topOffset, leftOffset, bw, bh, ow, oh int) {
+	bw, bh = lipgloss.Size(backdrop)
+	ow, oh = lipgloss.Size(overlay)
+
+	//  (backdropWidth - overlayWidth) * horizontalAlignment = leftOffset
+	leftOffset = int(float64(bw-ow) * float64(m.Horizontal)) // 0 <= leftOffset <= backdropWidth - overlayWidth
+	//  (backdropHeight - overlayHeight) * verticalAlignment = topOffset
+	topOffset = int(float64(bh-oh) * float64(m.Vertical)) // 0 <= topOffset <= backdropHeight - overlayHeight
+
+	topOffset, leftOffset = m.animateOffsets(bw, bh, ow, oh, topOffset, leftOffset)
+
+	return topOffset, leftOffset, bw, bh, ow, oh
+}
+
+// animateOffsets lerps from an off-screen starting position (chosen by
+// entranceDir) to the aligned (topOffset, leftOffset) as progress goes
+// from 0 to 1. EntranceFade leaves the position untouched.
+func (m *Model) animateOffsets(bw, bh, ow, oh, topOffset, leftOffset int) (int, int) {
+	if m.entranceDir == EntranceFade {
+		return topOffset, leftOffset
+	}
+
+	startTop, startLeft := topOffset, leftOffset
+	switch m.entranceDir {
+	case EntranceTop:
+		startTop = -oh
+	case EntranceBottom:
+		startTop = bh
+	case EntranceLeft:
+		startLeft = -ow
+	case EntranceRight:
+		startLeft = bw
+	}
+
+	top := startTop + int(m.progress*float64(topOffset-startTop))
+	left := startLeft + int(m.progress*float64(leftOffset-startLeft))
+	return top, left
+}
+
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
-	// case FrameMsg:
-	// 	// animate spring
+	case FrameMsg:
+		if msg.id != m.id || msg.tag != m.tag {
+			return m, nil
+		}
+		m.progress, m.velocity = m.spring.Update(m.progress, m.velocity, m.target())
+		if !m.animating() {
+			return m, nil
+		}
+		return m, m.nextFrame()
+
+	case CountdownMsg:
+		if msg.id != m.id || msg.tag != m.tag {
+			return m, nil
+		}
+		if m.timeoutRemaining() <= 0 {
+			return m, nil
+		}
+		return m, m.nextCountdown()
 
 	case TimeoutMsg:
 		// open or close the overlay
@@ -254,20 +668,188 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		}
 		return m, nil
 	case tea.MouseMsg:
+		if row, colStart, colEnd, ok := m.closeButtonCell(); ok {
+			if msg.Type == tea.MouseLeft && msg.Y == row && msg.X >= colStart && msg.X < colEnd {
+				return m, m.close(false)
+			}
+		}
 		if len(m.MouseHide) > 0 {
 			if m.mouseHideHas(msg.Type) {
 				return m, m.close(false)
 			}
 		}
+		if m.opened && m.focused && m.Content != nil {
+			var cmd tea.Cmd
+			m.Content, cmd = m.Content.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.Keymap.Close):
+			if m.opened {
+				return m, m.close(false)
+			}
+		case key.Matches(msg, m.Keymap.Open):
+			if !m.opened {
+				return m, m.open(false)
+			}
+		case key.Matches(msg, m.Keymap.Confirm):
+			if m.opened && m.focused {
+				return m, func() tea.Msg { return ConfirmMsg{} }
+			}
+		}
+		if m.opened && m.focused && m.Content != nil {
+			var cmd tea.Cmd
+			m.Content, cmd = m.Content.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		if m.Content != nil {
+			var cmd tea.Cmd
+			m.Content, cmd = m.Content.Update(m.innerSizeMsg(msg))
+			return m, cmd
+		}
+		return m, nil
+
 	default:
+		if m.opened && m.Content != nil {
+			var cmd tea.Cmd
+			m.Content, cmd = m.Content.Update(msg)
+			return m, cmd
+		}
 		return m, nil
 	}
+}
 
-	return m, nil
+// innerSizeMsg translates a tea.WindowSizeMsg for the whole overlay into
+// the dimensions available to Content once Style's border and padding are
+// accounted for.
+func (m *Model) innerSizeMsg(msg tea.WindowSizeMsg) tea.WindowSizeMsg {
+	w := msg.Width - m.Style.GetHorizontalFrameSize()
+	h := msg.Height - m.Style.GetVerticalFrameSize()
+	if w < 0 {
+		w = 0
+	}
+	if h < 0 {
+		h = 0
+	}
+	return tea.WindowSizeMsg{Width: w, Height: h}
 }
 
 func (m Model) View() string {
-	return m.Render(m.Overlay, m.Backdrop)
+	return m.Render(m.renderedContent(), m.Backdrop)
+}
+
+// renderedContent returns Content's view (falling back to Overlay when no
+// child is set), framed with a titlebar/footer/timeout indicator if
+// configured, piped through Style, ready to be composited by Render.
+func (m Model) renderedContent() string {
+	content := m.Overlay
+	if m.Content != nil {
+		content = m.Content.View()
+	}
+
+	title, footer := m.Title, m.Footer
+	if text := m.timeoutText(); text != "" {
+		switch m.TimeoutIndicator {
+		case TimeoutInTitle:
+			title = strings.TrimSpace(title + " " + text)
+		case TimeoutInFooter:
+			footer = strings.TrimSpace(footer + " " + text)
+		}
+	}
+
+	showBar := m.TimeoutIndicator == TimeoutProgressBar && m.activeTimeout() != 0
+	if title != "" || m.CloseButton || footer != "" || showBar {
+		width := lipgloss.Width(content)
+
+		rows := make([]string, 0, 4)
+		if title != "" || m.CloseButton {
+			rows = append(rows, m.renderTitlebar(title, width))
+		}
+		rows = append(rows, content)
+		if footer != "" {
+			rows = append(rows, footer)
+		}
+		if showBar {
+			rows = append(rows, m.renderTimeoutBar(width))
+		}
+		content = lipgloss.JoinVertical(lipgloss.Left, rows...)
+	}
+
+	return m.Style.Render(content)
+}
+
+// renderTitlebar draws title left-aligned and, if CloseButton is set, the
+// close glyph right-aligned within width cells.
+func (m Model) renderTitlebar(title string, width int) string {
+	title = m.TitleStyle.Render(title)
+	if !m.CloseButton {
+		return title
+	}
+
+	gap := width - lipgloss.Width(title) - lipgloss.Width(closeGlyph)
+	if gap < 0 {
+		gap = 0
+	}
+	return title + strings.Repeat(" ", gap) + closeGlyph
+}
+
+// timeoutText renders the remaining active timeout as "(Ns)", or "" when
+// there's no timeout indicator to show. An elapsed timeout renders as "(0s)".
+func (m Model) timeoutText() string {
+	if m.TimeoutIndicator == TimeoutIndicatorNone || m.activeTimeout() == 0 {
+		return ""
+	}
+	remaining := m.timeoutRemaining()
+	secs := int(remaining.Round(time.Second) / time.Second)
+	if secs < 1 && remaining > 0 {
+		secs = 1
+	}
+	return fmt.Sprintf("(%ds)", secs)
+}
+
+// renderTimeoutBar draws a single-row bar spanning width cells, filled in
+// proportion to the remaining fraction of the active timeout.
+func (m Model) renderTimeoutBar(width int) string {
+	if width <= 0 {
+		return ""
+	}
+
+	total := m.activeTimeout()
+	frac := 0.0
+	if total > 0 {
+		frac = float64(m.timeoutRemaining()) / float64(total)
+	}
+
+	filled := int(float64(width) * frac)
+	bar := strings.Repeat(filledBlock, filled) + strings.Repeat(emptyBlock, width-filled)
+	return lipgloss.NewStyle().Foreground(m.Style.GetBorderTopForeground()).Render(bar)
+}
+
+// closeButtonCell returns the absolute backdrop row and column range of
+// the close-button glyph, translating overlay-local coordinates through
+// the same offset math Render uses. ok is false when there's no close
+// button to hit, e.g. the overlay is closed or CloseButton is unset.
+func (m *Model) closeButtonCell() (row, colStart, colEnd int, ok bool) {
+	if !m.CloseButton || (!m.opened && !m.animating()) {
+		return 0, 0, 0, false
+	}
+
+	overlay := m.renderedContent()
+	topOffset, leftOffset, _, _, ow, _ := m.offsets(overlay, m.Backdrop)
+
+	titlebarRow := m.Style.GetBorderTopSize() + m.Style.GetPaddingTop()
+	rightInset := m.Style.GetBorderRightSize() + m.Style.GetPaddingRight()
+
+	row = topOffset + titlebarRow
+	colEnd = leftOffset + ow - rightInset
+	colStart = colEnd - lipgloss.Width(closeGlyph)
+	return row, colStart, colEnd, true
 }
 
 func (m Model) mouseHideHas(eventType tea.MouseEventType) bool {
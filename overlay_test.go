@@ -0,0 +1,356 @@
+package bubble_overlay
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// fakeContent is a minimal tea.Model used to exercise Content forwarding.
+type fakeContent struct {
+	view      string
+	lastMsg   tea.Msg
+	updateCnt int
+}
+
+func (f fakeContent) Init() tea.Cmd { return nil }
+
+func (f fakeContent) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	f.lastMsg = msg
+	f.updateCnt++
+	return f, nil
+}
+
+func (f fakeContent) View() string { return f.view }
+
+func TestOpenStartsAnimationTowardOpenTarget(t *testing.T) {
+	m := New()
+	m.Open()
+
+	if m.target() != 1 {
+		t.Fatalf("expected Open to target progress 1, got %v", m.target())
+	}
+	if !m.animating() {
+		t.Fatalf("expected a freshly opened overlay to be animating")
+	}
+}
+
+func TestFrameMsgAdvancesProgressTowardTarget(t *testing.T) {
+	m := New()
+	m.Open()
+
+	before := m.progress
+	m, cmd := m.Update(FrameMsg{id: m.id, tag: m.tag})
+	if m.progress <= before {
+		t.Fatalf("expected progress to advance toward the open target, got %v -> %v", before, m.progress)
+	}
+	if cmd == nil {
+		t.Fatalf("expected another frame to be scheduled while still animating")
+	}
+}
+
+func TestFrameMsgIgnoresMismatchedTag(t *testing.T) {
+	m := New()
+	m.Open()
+	before := m.progress
+
+	m, cmd := m.Update(FrameMsg{id: m.id, tag: m.tag + 1})
+	if cmd != nil {
+		t.Fatalf("expected a stale frame tick to be dropped")
+	}
+	if m.progress != before {
+		t.Fatalf("expected progress to be untouched by a stale frame tick")
+	}
+}
+
+func TestCloseMidOpenInvalidatesStaleFrameTicks(t *testing.T) {
+	m := New()
+	m.Open()
+	staleTag := m.tag
+
+	m.Close() // bumps tag, so the Open frame tick scheduled above is now stale
+
+	m, cmd := m.Update(FrameMsg{id: m.id, tag: staleTag})
+	if cmd != nil {
+		t.Fatalf("expected a frame tick from before Close to be dropped")
+	}
+	if m.opened {
+		t.Fatalf("expected the stale tick not to affect the now-closed state")
+	}
+}
+
+func TestAnimationSettlesAtOpenTarget(t *testing.T) {
+	m := New()
+	m.Open()
+
+	var cmd tea.Cmd = func() tea.Msg { return FrameMsg{id: m.id, tag: m.tag} }
+	for i := 0; i < 1000 && cmd != nil; i++ {
+		m, cmd = m.Update(cmd())
+	}
+
+	if cmd != nil {
+		t.Fatalf("expected the animation to settle within 1000 frames")
+	}
+	if m.progress < 1-animationEpsilon {
+		t.Fatalf("expected progress to settle at the open target, got %v", m.progress)
+	}
+}
+
+func TestEntranceDirectionSlidesFromOffscreen(t *testing.T) {
+	m := New(WithEntranceDirection(EntranceLeft))
+	m.progress = 0
+
+	top, left := m.animateOffsets(20, 10, 4, 2, 3, 8)
+	if left != -4 {
+		t.Fatalf("expected progress 0 to start fully offscreen left, got left=%d", left)
+	}
+	if top != 3 {
+		t.Fatalf("expected vertical offset to be untouched by a horizontal entrance, got top=%d", top)
+	}
+
+	m.progress = 1
+	_, left = m.animateOffsets(20, 10, 4, 2, 3, 8)
+	if left != 8 {
+		t.Fatalf("expected progress 1 to land at the aligned offset, got left=%d", left)
+	}
+}
+
+func TestEntranceLeftRendersFullyOffscreenAtZeroProgress(t *testing.T) {
+	m := New(
+		WithStyle(lipgloss.NewStyle()),
+		WithAlignment(lipgloss.Top, lipgloss.Left),
+		WithEntranceDirection(EntranceLeft),
+	)
+	m.Overlay = "OVER"
+	m.Backdrop = "..........\n.........."
+	m.Open() // progress starts at 0; no FrameMsg has advanced it yet
+
+	got := m.Render(m.Overlay, m.Backdrop)
+	if got != m.Backdrop {
+		t.Fatalf("expected the overlay to be entirely offscreen at progress 0, got %q", got)
+	}
+}
+
+func TestEntranceRightRendersFullyOffscreenAtZeroProgress(t *testing.T) {
+	m := New(
+		WithStyle(lipgloss.NewStyle()),
+		WithAlignment(lipgloss.Top, lipgloss.Left),
+		WithEntranceDirection(EntranceRight),
+	)
+	m.Overlay = "OVER"
+	m.Backdrop = "..........\n.........."
+	m.Open()
+
+	got := m.Render(m.Overlay, m.Backdrop)
+	if got != m.Backdrop {
+		t.Fatalf("expected the overlay to be entirely offscreen at progress 0, got %q", got)
+	}
+}
+
+func TestWithSpringAdvancesFasterWithHigherFrequency(t *testing.T) {
+	slow := New(WithSpring(1, 1))
+	fast := New(WithSpring(100, 1))
+	slow.Open()
+	fast.Open()
+
+	slow, _ = slow.Update(FrameMsg{id: slow.id, tag: slow.tag})
+	fast, _ = fast.Update(FrameMsg{id: fast.id, tag: fast.tag})
+
+	if fast.progress <= slow.progress {
+		t.Fatalf("expected a higher-frequency spring to advance progress faster: slow=%v fast=%v", slow.progress, fast.progress)
+	}
+}
+
+func TestCellSlicePlain(t *testing.T) {
+	out, cells := cellSlice("hello world", 6, 5)
+	if out != "world" || cells != 5 {
+		t.Fatalf("got %q/%d, want %q/%d", out, cells, "world", 5)
+	}
+}
+
+func TestCellSliceStyledBackdrop(t *testing.T) {
+	red := "\x1b[31mred text\x1b[0m after"
+
+	out, cells := cellSlice(red, 0, 3)
+	if out != "\x1b[31mred\x1b[0m" || cells != 3 {
+		t.Fatalf("got %q/%d", out, cells)
+	}
+
+	// cutting after the reset must not carry the color across the boundary
+	out, cells = cellSlice(red, 4, 5)
+	if out != "\x1b[31mtext\x1b[0m " || cells != 5 {
+		t.Fatalf("got %q/%d", out, cells)
+	}
+}
+
+func TestCellSliceDoesNotSplitWideRune(t *testing.T) {
+	// U+65E5 ("日") is a double-width rune; a 2-cell window starting on its
+	// right half (and ending on the left half of "本") must not emit half a
+	// rune.
+	out, cells := cellSlice("日本語", 1, 2)
+	if out != "" || cells != 0 {
+		t.Fatalf("got %q/%d, want empty slice", out, cells)
+	}
+
+	out, cells = cellSlice("日本語", 0, 2)
+	if out != "日" || cells != 2 {
+		t.Fatalf("got %q/%d", out, cells)
+	}
+}
+
+func TestCellSliceShorterThanRequested(t *testing.T) {
+	out, cells := cellSlice("short", 0, 10)
+	if out != "short" || cells != 5 {
+		t.Fatalf("got %q/%d, want %q/%d", out, cells, "short", 5)
+	}
+}
+
+func TestUpdateDoesNotForwardKeysWhenUnfocused(t *testing.T) {
+	m := New(WithContent(fakeContent{view: "child"}))
+	m.Open()
+
+	keyX := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}
+
+	m, _ = m.Update(keyX)
+	if m.Content.(fakeContent).updateCnt != 0 {
+		t.Fatalf("expected unfocused overlay not to forward key messages to Content")
+	}
+
+	m.Focus()
+	m, _ = m.Update(keyX)
+	if m.Content.(fakeContent).updateCnt != 1 {
+		t.Fatalf("expected focused overlay to forward key messages to Content")
+	}
+}
+
+func TestUpdateClosesOnKeymapClose(t *testing.T) {
+	m := New(WithContent(fakeContent{view: "child"}))
+	m.Open()
+	m.Focus()
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.opened {
+		t.Fatalf("expected esc to close the overlay via the default keymap")
+	}
+}
+
+func TestUpdateEmitsConfirmMsgOnKeymapConfirm(t *testing.T) {
+	m := New(WithContent(fakeContent{view: "child"}))
+	m.Open()
+	m.Focus()
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatalf("expected enter to emit a command via the default keymap")
+	}
+	if _, ok := cmd().(ConfirmMsg); !ok {
+		t.Fatalf("expected enter to emit a ConfirmMsg")
+	}
+}
+
+func TestUpdateIgnoresKeymapConfirmWhenUnfocused(t *testing.T) {
+	m := New(WithContent(fakeContent{view: "child"}))
+	m.Open()
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil {
+		t.Fatalf("expected enter to have no effect while unfocused")
+	}
+}
+
+func TestViewFallsBackToOverlayWithoutContent(t *testing.T) {
+	m := New()
+	m.Overlay = "plain text"
+	m.Backdrop = "plain text"
+	m.Open()
+
+	if got := m.View(); !strings.Contains(got, "plain text") {
+		t.Fatalf("expected view to fall back to Overlay, got %q", got)
+	}
+}
+
+func TestCloseButtonClickClosesOverlay(t *testing.T) {
+	m := New(
+		WithAlignment(lipgloss.Top, lipgloss.Left),
+		WithTitle("Confirm"),
+		WithCloseButton(true),
+	)
+	m.Overlay = "body"
+	m.Backdrop = "..........\n.........."
+	m.Open()
+
+	row, colStart, _, ok := m.closeButtonCell()
+	if !ok {
+		t.Fatalf("expected a close button cell once opened")
+	}
+
+	m, _ = m.Update(tea.MouseMsg{X: colStart, Y: row, Type: tea.MouseLeft})
+	if m.opened {
+		t.Fatalf("expected clicking the close button to close the overlay")
+	}
+}
+
+func TestTitlebarWidensOverlayHeight(t *testing.T) {
+	plain := New()
+	plain.Overlay = "body"
+
+	titled := New(WithTitle("Confirm"))
+	titled.Overlay = "body"
+
+	if got, want := lipgloss.Height(titled.renderedContent()), lipgloss.Height(plain.renderedContent())+1; got != want {
+		t.Fatalf("titlebar row not counted: got height %d, want %d", got, want)
+	}
+}
+
+func TestRenderPadsShortLines(t *testing.T) {
+	m := New(WithAlignment(lipgloss.Top, lipgloss.Left))
+	m.Overlay = "OO\nOO"
+	m.Backdrop = "ABCDEF\nXY"
+	m.Open()
+
+	got := m.Render(m.Overlay, m.Backdrop)
+	lines := strings.Split(got, "\n")
+	for _, line := range lines {
+		if w := lipgloss.Width(line); w != 6 {
+			t.Fatalf("line %q has width %d, want 6", line, w)
+		}
+	}
+}
+
+func TestTimeoutProgressBarStartsMostlyFilled(t *testing.T) {
+	m := New(WithTimeout(time.Minute, 0), WithTimeoutIndicator(TimeoutProgressBar))
+	m.Overlay = "body"
+	m.Open()
+
+	bar := m.renderTimeoutBar(lipgloss.Width(m.Overlay))
+	if !strings.Contains(bar, filledBlock) {
+		t.Fatalf("expected a freshly opened timeout bar to be mostly filled, got %q", bar)
+	}
+}
+
+func TestTimeoutTextOnlyShownForConfiguredIndicator(t *testing.T) {
+	m := New(WithTimeout(time.Minute, 0), WithTimeoutIndicator(TimeoutInTitle))
+	m.Open()
+
+	if text := m.timeoutText(); text == "" {
+		t.Fatalf("expected timeout text once OpenTimeout is active with TimeoutInTitle")
+	}
+
+	m.TimeoutIndicator = TimeoutIndicatorNone
+	if text := m.timeoutText(); text != "" {
+		t.Fatalf("expected no timeout text without a configured indicator, got %q", text)
+	}
+}
+
+func TestCountdownMsgIgnoresMismatchedTag(t *testing.T) {
+	m := New(WithTimeout(time.Minute, 0), WithTimeoutIndicator(TimeoutInFooter))
+	m.Open()
+
+	_, cmd := m.Update(CountdownMsg{id: m.id, tag: m.tag + 1})
+	if cmd != nil {
+		t.Fatalf("expected a stale countdown tick to be dropped")
+	}
+}
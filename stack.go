@@ -0,0 +1,137 @@
+package bubble_overlay
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Stack manages an ordered set of overlays, e.g. a confirm dialog pushed
+// on top of another dialog. FrameMsg and CountdownMsg are routed to every
+// overlay so a lower overlay's own open/close animation and timeout
+// countdown keep running while it's covered; TimeoutMsg, tea.MouseMsg,
+// and tea.KeyMsg are routed only to the topmost open overlay, so lower
+// overlays stay visible but otherwise inert underneath it.
+type Stack struct {
+	overlays []*Model
+
+	// PassThroughKeys lists key strings (as reported by tea.KeyMsg.String)
+	// that Update never routes to an overlay, leaving them for the parent
+	// app to handle regardless of what's on top of the stack.
+	PassThroughKeys []string
+}
+
+type StackOption func(*Stack)
+
+// WithPassThroughKeys sets the keys that bypass the topmost overlay and
+// are left for the parent app to handle.
+func WithPassThroughKeys(keys ...string) StackOption {
+	return func(s *Stack) {
+		s.PassThroughKeys = append(s.PassThroughKeys, keys...)
+	}
+}
+
+func NewStack(opts ...StackOption) Stack {
+	var s Stack
+	for _, opt := range opts {
+		opt(&s)
+	}
+	return s
+}
+
+// Push adds m to the top of the stack and opens it.
+func (s *Stack) Push(m *Model) tea.Cmd {
+	s.overlays = append(s.overlays, m)
+	return m.Open()
+}
+
+// Pop removes the topmost overlay, bumping its tag so any timeout or
+// animation frame ticks already in flight for it are ignored if they
+// arrive later.
+func (s *Stack) Pop() tea.Cmd {
+	if len(s.overlays) == 0 {
+		return nil
+	}
+	top := s.overlays[len(s.overlays)-1]
+	s.overlays = s.overlays[:len(s.overlays)-1]
+	top.tag++
+	return nil
+}
+
+// Top returns the topmost overlay, or nil if the stack is empty.
+func (s *Stack) Top() *Model {
+	if len(s.overlays) == 0 {
+		return nil
+	}
+	return s.overlays[len(s.overlays)-1]
+}
+
+// topOpen returns the topmost overlay that is open (or still animating
+// closed), which is the only one that should receive routed messages.
+func (s *Stack) topOpen() *Model {
+	for i := len(s.overlays) - 1; i >= 0; i-- {
+		if o := s.overlays[i]; o.opened || o.animating() {
+			return o
+		}
+	}
+	return nil
+}
+
+func (s *Stack) isPassThrough(msg tea.KeyMsg) bool {
+	k := msg.String()
+	for _, pk := range s.PassThroughKeys {
+		if pk == k {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Stack) Update(msg tea.Msg) (Stack, tea.Cmd) {
+	switch msg := msg.(type) {
+	case FrameMsg, CountdownMsg:
+		// every overlay drives its own open/close animation and timeout
+		// countdown independently of stacking order, each gated by its
+		// own id/tag, so a lower overlay mid-animation or mid-countdown
+		// doesn't get stuck once it's covered
+		cmds := make([]tea.Cmd, 0, len(s.overlays))
+		for _, o := range s.overlays {
+			updated, cmd := o.Update(msg)
+			*o = updated
+			cmds = append(cmds, cmd)
+		}
+		return s, tea.Batch(cmds...)
+	case TimeoutMsg, tea.MouseMsg:
+		top := s.topOpen()
+		if top == nil {
+			return s, nil
+		}
+		updated, cmd := top.Update(msg)
+		*top = updated
+		return s, cmd
+	case tea.KeyMsg:
+		top := s.topOpen()
+		if top == nil {
+			return s, nil
+		}
+		if s.isPassThrough(msg) {
+			return s, nil
+		}
+		updated, cmd := top.Update(msg)
+		*top = updated
+		return s, cmd
+	}
+
+	return s, nil
+}
+
+// View composites every open overlay onto base, bottom-up, so the second
+// overlay's backdrop is the first overlay's rendered output.
+func (s Stack) View(base string) string {
+	composed := base
+	for _, o := range s.overlays {
+		if !o.opened && !o.animating() {
+			continue
+		}
+		composed = o.Render(o.renderedContent(), composed)
+	}
+	return composed
+}
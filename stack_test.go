@@ -0,0 +1,107 @@
+package bubble_overlay
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestStackRoutesOnlyToTopOverlay(t *testing.T) {
+	back := New(WithContent(fakeContent{view: "back"}))
+	front := New(WithContent(fakeContent{view: "front"}))
+
+	var s Stack
+	s.Push(&back)
+	back.Focus()
+	s.Push(&front)
+	front.Focus()
+
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+
+	if front.Content.(fakeContent).updateCnt != 1 {
+		t.Fatalf("expected the topmost overlay to receive the key message")
+	}
+	if back.Content.(fakeContent).updateCnt != 0 {
+		t.Fatalf("expected the lower overlay not to receive the key message")
+	}
+}
+
+func TestStackRoutesFrameMsgToEveryOverlay(t *testing.T) {
+	back := New()
+	front := New()
+
+	var s Stack
+	s.Push(&back)
+	s.Push(&front)
+
+	beforeProgress := back.progress
+	s, _ = s.Update(FrameMsg{id: back.id, tag: back.tag})
+
+	if back.progress == beforeProgress {
+		t.Fatalf("expected a covered overlay to keep animating on FrameMsg")
+	}
+}
+
+func TestStackRoutesCountdownMsgToEveryOverlay(t *testing.T) {
+	back := New(WithTimeout(time.Minute, 0), WithTimeoutIndicator(TimeoutInFooter))
+	front := New()
+
+	var s Stack
+	s.Push(&back)
+	s.Push(&front)
+
+	_, cmd := s.Update(CountdownMsg{id: back.id, tag: back.tag})
+	if cmd == nil {
+		t.Fatalf("expected a covered overlay's countdown tick to keep re-scheduling itself")
+	}
+}
+
+func TestStackPopBumpsTag(t *testing.T) {
+	m := New()
+	var s Stack
+	s.Push(&m)
+	tagBeforePop := m.tag
+
+	s.Pop()
+
+	if m.tag == tagBeforePop {
+		t.Fatalf("expected Pop to bump the popped overlay's tag")
+	}
+}
+
+func TestStackPassThroughKeys(t *testing.T) {
+	top := New(WithContent(fakeContent{view: "top"}))
+	top.Focus()
+
+	s := NewStack(WithPassThroughKeys("ctrl+c"))
+	s.Push(&top)
+
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("ctrl+c")})
+
+	if top.Content.(fakeContent).updateCnt != 0 {
+		t.Fatalf("expected a pass-through key not to reach the overlay")
+	}
+}
+
+func TestStackViewCompositesBottomUp(t *testing.T) {
+	// unbordered, unpadded Style so a two-line backdrop is tall enough to
+	// hold the rendered overlay content
+	plain := lipgloss.NewStyle()
+
+	back := New(WithAlignment(lipgloss.Top, lipgloss.Left), WithStyle(plain))
+	back.Overlay = "BACK"
+	front := New(WithAlignment(lipgloss.Top, lipgloss.Left), WithStyle(plain))
+	front.Overlay = "FRONT"
+
+	var s Stack
+	s.Push(&back)
+	s.Push(&front)
+
+	got := s.View(".....\n.....")
+	if !strings.Contains(got, "FRONT") {
+		t.Fatalf("expected the top overlay's content in the composited view, got %q", got)
+	}
+}